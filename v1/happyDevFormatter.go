@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"os"
 	"strings"
+	"sync"
 
 	"github.com/go-errors/errors"
 	"github.com/mgutz/ansi"
@@ -30,6 +32,54 @@ var indent = "  "
 var maxCol = defaultMaxCol
 var theme *colorScheme
 
+// defaultMaxPadWidth bounds how wide a single key's column is allowed to
+// grow, so one oversized value doesn't drag every later line along with it.
+const defaultMaxPadWidth = 40
+
+// MaxPadWidth bounds the column width tracked per key. Values longer than
+// this still print in full, they just don't widen the column further.
+var MaxPadWidth = defaultMaxPadWidth
+
+// disablePadding turns off column alignment, restoring the original tight
+// layout.
+var disablePadding = false
+
+var (
+	fieldWidthsMu sync.RWMutex
+	fieldWidths   = map[string]int{}
+)
+
+// DisablePadding disables the column-aligned key/value output introduced by
+// fieldWidths, restoring logxi's original tight layout.
+func DisablePadding(val bool) {
+	disablePadding = val
+}
+
+// padWidth records the widest rendered value seen for key so far and
+// returns it. The recorded width only ever grows (never shrinks), so
+// columns widen but never jitter; it is capped at MaxPadWidth so one huge
+// value can't blow out every line's layout.
+func padWidth(key string, width int) int {
+	if width > MaxPadWidth {
+		width = MaxPadWidth
+	}
+
+	fieldWidthsMu.RLock()
+	cur := fieldWidths[key]
+	fieldWidthsMu.RUnlock()
+	if width <= cur {
+		return cur
+	}
+
+	fieldWidthsMu.Lock()
+	if width > fieldWidths[key] {
+		fieldWidths[key] = width
+	}
+	cur = fieldWidths[key]
+	fieldWidthsMu.Unlock()
+	return cur
+}
+
 func parseKVList(s, separator string) map[string]string {
 	pairs := strings.Split(s, separator)
 	if len(pairs) == 0 {
@@ -95,9 +145,15 @@ func keyColor(s string) string {
 	return theme.Key + s + ansi.Reset
 }
 
-// DisableColors disables coloring of log entries.
+// colorsExplicit records that the user called DisableColors themselves, so
+// autoDetectColors's terminal detection knows to leave their choice alone.
+var colorsExplicit bool
+
+// DisableColors disables coloring of log entries. This always wins over
+// the automatic terminal detection autoDetectColors performs.
 func DisableColors(val bool) {
 	disableColors = val
+	colorsExplicit = true
 }
 
 // HappyDevFormatter is the formatter used for terminals. It is
@@ -117,10 +173,15 @@ type HappyDevFormatter struct {
 	jsonFormatter *JSONFormatter
 }
 
-// NewHappyDevFormatter returns a new instance of HappyDevFormatter.
+// NewHappyDevFormatter returns a new instance of HappyDevFormatter. Unless
+// the caller has already made an explicit choice via DisableColors, this
+// auto-detects whether os.Stdout -- the usual console sink -- is an
+// interactive terminal, so `myapp | tee log.txt` yields a clean file while
+// an interactive run stays colored.
 func NewHappyDevFormatter(name string) *HappyDevFormatter {
 	jf := NewJSONFormatter(name)
 	jf.disableCallStack(true)
+	autoDetectColors(os.Stdout)
 	return &HappyDevFormatter{
 		name:          name,
 		jsonFormatter: jf,
@@ -142,6 +203,12 @@ func (hd *HappyDevFormatter) writeKey(buf *bytes.Buffer, key string) {
 func (hd *HappyDevFormatter) offset(buf *bytes.Buffer, color string, key string, value string) {
 	val := strings.Trim(value, "\n ")
 
+	if !disablePadding && key != "" {
+		if pad := padWidth(key, len(val)) - len(val); pad > 0 {
+			val += strings.Repeat(" ", pad)
+		}
+	}
+
 	if (isPretty && key != "") || hd.col+len(key)+1+len(val) >= maxCol {
 		buf.WriteString("\n")
 		hd.col = 0
@@ -158,12 +225,25 @@ func (hd *HappyDevFormatter) offset(buf *bytes.Buffer, color string, key string,
 	}
 }
 
-// writeError writes an error. It eventually calls offset which adds formatting
-// newlines, etc.
-func (hd *HappyDevFormatter) writeError(buf *bytes.Buffer, key string, err *errors.Error) {
+// writeError writes an error and, if original unwraps further (via
+// errors.Unwrap, the Go 1.20 multi-error tree, or merry-style annotations),
+// a "caused by" line per layer beneath it. It eventually calls offset which
+// adds formatting newlines, etc.
+func (hd *HappyDevFormatter) writeError(buf *bytes.Buffer, key string, err *errors.Error, original error) {
+	seen := map[string]bool{}
+
 	msg := err.Error()
-	stack := string(err.Stack())
-	hd.offset(buf, theme.Error, key, msg+"\n"+stack)
+	stack := dedupeFrames(string(err.Stack()), seen)
+
+	var out bytes.Buffer
+	out.WriteString(msg)
+	if stack != "" {
+		out.WriteString("\n")
+		out.WriteString(stack)
+	}
+	out.WriteString(formatCauses(original, seen))
+
+	hd.offset(buf, theme.Error, key, out.String())
 }
 
 // set writes a key-value pair to buf. It eventually calls offset which adds
@@ -171,12 +251,24 @@ func (hd *HappyDevFormatter) writeError(buf *bytes.Buffer, key string, err *erro
 func (hd *HappyDevFormatter) set(buf *bytes.Buffer, key string, value interface{}, color string) {
 	if err, ok := value.(error); ok {
 		err2 := errors.Wrap(err, 4)
-		hd.writeError(buf, key, err2)
+		hd.writeError(buf, key, err2, err)
+		return
 	} else if err, ok := value.(*errors.Error); ok {
-		hd.writeError(buf, key, err)
-	} else {
-		hd.offset(buf, color, key, fmt.Sprintf("%v", value))
+		hd.writeError(buf, key, err, err)
+		return
+	}
+
+	valueFormattersMu.RLock()
+	formatters := valueFormatters
+	valueFormattersMu.RUnlock()
+	for _, render := range formatters {
+		if rendered, ok := render(value); ok {
+			hd.offset(buf, color, key, rendered)
+			return
+		}
 	}
+
+	hd.offset(buf, color, key, fmt.Sprintf("%v", value))
 }
 
 // tracks the position of the string so we can break lines cleanly. Do not
@@ -186,24 +278,42 @@ func (hd *HappyDevFormatter) writeString(buf *bytes.Buffer, s string) {
 	hd.col += len(s)
 }
 
-func (hd *HappyDevFormatter) getLevelContext(level int) (context string, color string) {
+// frameContext returns a single short call-stack frame, skipping the
+// topmost skip frames of internal logxi/runtime machinery. Element 0 of the
+// trace is frameContext itself; from a logger call the frames to skip are
+// frameContext, getLevelContext, format, Format/FormatWithSource, the
+// logger's log and its Debug/Info/Warn method, so callers pass 6 to land on
+// the logging call site.
+func (hd *HappyDevFormatter) frameContext(skip int, color string) string {
+	trace := stack.Trace().TrimRuntime()
+	for i, s := range trace {
+		if i < skip {
+			continue
+		}
+		ci := newCallstackInfo(s, -1)
+		return ci.String(color, theme.Source)
+	}
+	return ""
+}
+
+// getLevelContext returns the file:line context to render beneath the
+// entry, and the color to render the level/message in. Warn and Error
+// always get context; Debug and Info only do when addSource is true
+// (set via HandlerOptions.AddSource on the slog adapters).
+func (hd *HappyDevFormatter) getLevelContext(level int, addSource bool) (context string, color string) {
 	switch level {
 	case LevelDebug:
 		color = theme.Debug
+		if addSource {
+			context = hd.frameContext(6, theme.Debug)
+		}
 	case LevelInfo:
 		color = theme.Info
-	case LevelWarn:
-		trace := stack.Trace().TrimRuntime()
-		// if one line, keep it on same line, multiple lines group all
-		// on next line
-		for i, stack := range trace {
-			if i < 4 {
-				continue
-			}
-			ci := newCallstackInfo(stack, -1)
-			context = ci.String(theme.Warn, theme.Source)
-			break
+		if addSource {
+			context = hd.frameContext(6, theme.Info)
 		}
+	case LevelWarn:
+		context = hd.frameContext(6, theme.Warn)
 		color = theme.Warn
 
 	default:
@@ -235,6 +345,18 @@ func (hd *HappyDevFormatter) getLevelContext(level int) (context string, color s
 
 // Format records a log entry.
 func (hd *HappyDevFormatter) Format(buf *bytes.Buffer, level int, msg string, args []interface{}) {
+	hd.format(buf, level, msg, args, false)
+}
+
+// FormatWithSource records a log entry the same way Format does, except
+// that when addSource is true it also attaches file:line context to Debug
+// and Info entries (Warn and Error already get it unconditionally). This is
+// what HandlerOptions.AddSource plumbs into on the slog adapters.
+func (hd *HappyDevFormatter) FormatWithSource(buf *bytes.Buffer, level int, msg string, args []interface{}, addSource bool) {
+	hd.format(buf, level, msg, args, addSource)
+}
+
+func (hd *HappyDevFormatter) format(buf *bytes.Buffer, level int, msg string, args []interface{}, addSource bool) {
 
 	// warn about reserved, bad and complex keys
 	for i := 0; i < len(args); i += 2 {
@@ -272,7 +394,7 @@ func (hd *HappyDevFormatter) Format(buf *bytes.Buffer, level int, msg string, ar
 	buf.WriteString(ansi.Reset)
 
 	// emphasize warnings and errors
-	context, color := hd.getLevelContext(level)
+	context, color := hd.getLevelContext(level, addSource)
 
 	// DBG, INF ...
 	hd.set(buf, "", entry[levelKey].(string), color)
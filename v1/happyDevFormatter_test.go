@@ -0,0 +1,24 @@
+package log
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestFrameContextReportsCallSite(t *testing.T) {
+	theme = parseTheme("")
+
+	var buf bytes.Buffer
+	logger := New("test").(*DefaultLogger)
+	logger.w = &buf
+	logger.SetLevel(LevelDebug)
+	logger.EnableSource(true)
+
+	logger.Debug("hello") // the call site frameContext's skip count must land on
+
+	out := buf.String()
+	if !strings.Contains(out, "happyDevFormatter_test.go") {
+		t.Fatalf("frameContext didn't report this file as the call site: %q", out)
+	}
+}
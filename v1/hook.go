@@ -0,0 +1,115 @@
+package log
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+)
+
+// Hook lets callers fan log entries out to side-effect destinations (Sentry,
+// Prometheus counters, syslog, audit pipelines, ...) while logxi keeps
+// writing its normal formatted output. Fire receives a copy of the entry
+// JSONFormatter.LogEntry produced, taken before the formatter renders any
+// text, so a slow or misbehaving hook can't corrupt or race with it.
+//
+// Hooks are registered on a Logger via Logger.AddHook, and fire for
+// whichever Formatter (HappyDevFormatter or JSONFormatter) that logger is
+// rendering through -- see DefaultLogger.log in logger.go.
+type Hook interface {
+	// Levels returns the levels this hook wants to receive. Fire is only
+	// called for entries at one of these levels.
+	Levels() []int
+
+	// Fire is called with a copy of the log entry. A returned error is
+	// reported to InternalLog rather than propagated to the caller of the
+	// logging method.
+	Fire(entry map[string]interface{}) error
+}
+
+// levelString returns the same short level label ("DBG", "INF", ...) that
+// JSONFormatter.LogEntry stores under levelKey.
+func levelString(level int) string {
+	switch level {
+	case LevelDebug:
+		return "DBG"
+	case LevelInfo:
+		return "INF"
+	case LevelWarn:
+		return "WRN"
+	default:
+		return "ERR"
+	}
+}
+
+// CounterHook is a built-in Hook that tallies how many entries were logged
+// per level, useful for exposing counts to a metrics system.
+type CounterHook struct {
+	mu     sync.Mutex
+	levels []int
+	counts map[string]int64
+}
+
+// NewCounterHook returns a CounterHook that counts entries at levels.
+func NewCounterHook(levels ...int) *CounterHook {
+	return &CounterHook{
+		levels: levels,
+		counts: make(map[string]int64, len(levels)),
+	}
+}
+
+// Levels implements Hook.
+func (c *CounterHook) Levels() []int {
+	return c.levels
+}
+
+// Fire implements Hook.
+func (c *CounterHook) Fire(entry map[string]interface{}) error {
+	level, ok := entry[levelKey].(string)
+	if !ok {
+		return nil
+	}
+	c.mu.Lock()
+	c.counts[level]++
+	c.mu.Unlock()
+	return nil
+}
+
+// Count returns how many entries have been fired at level so far.
+func (c *CounterHook) Count(level int) int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.counts[levelString(level)]
+}
+
+// WriterHook is a built-in Hook that duplicates fired entries, re-encoded as
+// plain JSON, to a secondary io.Writer (e.g. a file separate from the main
+// console output).
+type WriterHook struct {
+	mu     sync.Mutex
+	w      io.Writer
+	levels []int
+}
+
+// NewWriterHook returns a WriterHook that copies entries at levels to w.
+func NewWriterHook(w io.Writer, levels ...int) *WriterHook {
+	return &WriterHook{w: w, levels: levels}
+}
+
+// Levels implements Hook.
+func (w *WriterHook) Levels() []int {
+	return w.levels
+}
+
+// Fire implements Hook.
+func (w *WriterHook) Fire(entry map[string]interface{}) error {
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	b = append(b, '\n')
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	_, err = w.w.Write(b)
+	return err
+}
@@ -0,0 +1,49 @@
+package log
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestCounterHookCountsOnlyRegisteredLevels(t *testing.T) {
+	theme = parseTheme("")
+
+	var buf bytes.Buffer
+	logger := New("test").(*DefaultLogger)
+	logger.w = &buf
+	logger.SetLevel(LevelDebug)
+
+	hook := NewCounterHook(LevelWarn, LevelError)
+	logger.AddHook(hook)
+
+	logger.Debug("ignored")
+	logger.Warn("warned")
+	logger.Warn("warned again")
+	logger.Error("errored")
+
+	if got := hook.Count(LevelWarn); got != 2 {
+		t.Fatalf("Count(LevelWarn) = %d, want 2", got)
+	}
+	if got := hook.Count(LevelError); got != 1 {
+		t.Fatalf("Count(LevelError) = %d, want 1", got)
+	}
+	if got := hook.Count(LevelDebug); got != 0 {
+		t.Fatalf("Count(LevelDebug) = %d, want 0 (hook wasn't registered for it)", got)
+	}
+}
+
+func TestWriterHookReceivesEntryJSON(t *testing.T) {
+	theme = parseTheme("")
+
+	var buf bytes.Buffer
+	logger := New("test").(*DefaultLogger)
+	logger.w = &bytes.Buffer{}
+	logger.SetLevel(LevelDebug)
+
+	logger.AddHook(NewWriterHook(&buf, LevelInfo))
+	logger.Info("hello")
+
+	if !bytes.Contains(buf.Bytes(), []byte(`"hello"`)) {
+		t.Fatalf("WriterHook didn't receive the entry: %q", buf.String())
+	}
+}
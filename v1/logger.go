@@ -0,0 +1,151 @@
+package log
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"sync"
+)
+
+// Formatter is implemented by HappyDevFormatter and JSONFormatter. LogEntry
+// returns the canonical field map Format renders from, so a Logger can hand
+// hooks the same data regardless of which formatter it's configured with.
+type Formatter interface {
+	Format(buf *bytes.Buffer, level int, msg string, args []interface{})
+	LogEntry(level int, msg string, args []interface{}) map[string]interface{}
+}
+
+// LogEntry implements Formatter by delegating to the embedded JSONFormatter,
+// the one that actually builds the canonical field map.
+func (hd *HappyDevFormatter) LogEntry(level int, msg string, args []interface{}) map[string]interface{} {
+	return hd.jsonFormatter.LogEntry(level, msg, args)
+}
+
+// Logger is the interface returned by New and stored on a Registry. It owns
+// a Formatter and writer, and fans every entry it logs out to any Hooks
+// registered via AddHook before handing it to the formatter.
+type Logger interface {
+	Trace(msg string, args ...interface{})
+	Debug(msg string, args ...interface{})
+	Info(msg string, args ...interface{})
+	Warn(msg string, args ...interface{})
+	Error(msg string, args ...interface{})
+	Fatal(msg string, args ...interface{})
+
+	SetLevel(level int)
+	Level() int
+
+	// EnableSource turns file:line context on for Debug and Info entries,
+	// the same context Warn and Error already always get.
+	EnableSource(enabled bool)
+
+	// AddHook registers h to fire for every entry logged at one of the
+	// levels h.Levels() returns, regardless of which Formatter this Logger
+	// renders through.
+	AddHook(h Hook)
+}
+
+// DefaultLogger is the Logger implementation New returns.
+type DefaultLogger struct {
+	name      string
+	w         io.Writer
+	formatter Formatter
+	level     int
+	addSource bool
+
+	hooksMu sync.RWMutex
+	hooks   map[int][]Hook
+}
+
+// New returns a Logger named name, rendering through HappyDevFormatter to
+// os.Stdout -- the same default NewHappyDevFormatter itself uses.
+func New(name string) Logger {
+	return &DefaultLogger{
+		name:      name,
+		w:         os.Stdout,
+		formatter: NewHappyDevFormatter(name),
+		level:     LevelInfo,
+	}
+}
+
+func (l *DefaultLogger) SetLevel(level int) {
+	l.level = level
+}
+
+func (l *DefaultLogger) Level() int {
+	return l.level
+}
+
+func (l *DefaultLogger) EnableSource(enabled bool) {
+	l.addSource = enabled
+}
+
+func (l *DefaultLogger) AddHook(h Hook) {
+	l.hooksMu.Lock()
+	defer l.hooksMu.Unlock()
+	if l.hooks == nil {
+		l.hooks = map[int][]Hook{}
+	}
+	for _, level := range h.Levels() {
+		l.hooks[level] = append(l.hooks[level], h)
+	}
+}
+
+// fireHooks runs every hook registered for level against entry, reporting
+// any hook error to InternalLog rather than to the caller of Debug/Info/...
+func (l *DefaultLogger) fireHooks(level int, entry map[string]interface{}) {
+	l.hooksMu.RLock()
+	hooks := l.hooks[level]
+	l.hooksMu.RUnlock()
+	for _, h := range hooks {
+		if err := h.Fire(entry); err != nil {
+			InternalLog.Error("Hook fire failed.", "error", err.Error())
+		}
+	}
+}
+
+func (l *DefaultLogger) log(level int, msg string, args []interface{}) {
+	if level < l.level {
+		return
+	}
+
+	// give hooks a copy of the canonical entry before any text is rendered,
+	// the same entry the formatter itself renders from
+	l.fireHooks(level, l.formatter.LogEntry(level, msg, args))
+
+	var buf bytes.Buffer
+	if hd, ok := l.formatter.(*HappyDevFormatter); ok {
+		hd.FormatWithSource(&buf, level, msg, args, l.addSource)
+	} else {
+		l.formatter.Format(&buf, level, msg, args)
+	}
+	l.w.Write(buf.Bytes())
+}
+
+func (l *DefaultLogger) Trace(msg string, args ...interface{}) {
+	l.log(LevelDebug, msg, args)
+}
+
+func (l *DefaultLogger) Debug(msg string, args ...interface{}) {
+	l.log(LevelDebug, msg, args)
+}
+
+func (l *DefaultLogger) Info(msg string, args ...interface{}) {
+	l.log(LevelInfo, msg, args)
+}
+
+func (l *DefaultLogger) Warn(msg string, args ...interface{}) {
+	l.log(LevelWarn, msg, args)
+}
+
+func (l *DefaultLogger) Error(msg string, args ...interface{}) {
+	l.log(LevelError, msg, args)
+}
+
+// Fatal logs msg at LevelError, then panics rather than calling os.Exit, so
+// callers that recover (like Scan's line-by-line tailing) can contain a
+// single bad entry instead of the process dying outright.
+func (l *DefaultLogger) Fatal(msg string, args ...interface{}) {
+	l.log(LevelError, msg, args)
+	panic(msg)
+}
@@ -0,0 +1,39 @@
+package log
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPadWidthGrowsButNeverShrinksAndIsCapped(t *testing.T) {
+	fieldWidths = map[string]int{}
+
+	if w := padWidth("key", 3); w != 3 {
+		t.Fatalf("padWidth = %d, want 3", w)
+	}
+	if w := padWidth("key", 1); w != 3 {
+		t.Fatalf("padWidth shrank: got %d, want 3", w)
+	}
+	if w := padWidth("key", MaxPadWidth+10); w != MaxPadWidth {
+		t.Fatalf("padWidth = %d, want capped at %d", w, MaxPadWidth)
+	}
+}
+
+func TestDisablePaddingSkipsColumnAlignment(t *testing.T) {
+	fieldWidths = map[string]int{}
+	DisablePadding(true)
+	defer DisablePadding(false)
+
+	theme = parseTheme("")
+	hd := &HappyDevFormatter{}
+
+	var buf bytes.Buffer
+	hd.offset(&buf, "", "key", "v")
+	hd.offset(&buf, "", "key", "longer-value")
+
+	// with padding disabled, padWidth must never have been consulted, so
+	// fieldWidths stays untouched regardless of how many values "key" sees
+	if len(fieldWidths) != 0 {
+		t.Fatalf("DisablePadding(true) still recorded field widths: %v", fieldWidths)
+	}
+}
@@ -0,0 +1,281 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/mgutz/ansi"
+)
+
+// ValueFormatter renders v as a colorized, possibly multi-line string for
+// display under a key, or reports ok=false so HappyDevFormatter falls
+// through to the next registered formatter (and ultimately to the default
+// fmt.Sprintf("%v", ...) rendering).
+type ValueFormatter func(v interface{}) (string, bool)
+
+var (
+	valueFormattersMu sync.RWMutex
+	valueFormatters   []ValueFormatter
+)
+
+// RegisterValueFormatter adds f to the renderers HappyDevFormatter tries, in
+// registration order, before falling back to the default rendering. Use it
+// to pretty print application-specific types the built-in JSON/GraphQL/
+// form-data renderers don't recognize.
+func RegisterValueFormatter(f ValueFormatter) {
+	valueFormattersMu.Lock()
+	defer valueFormattersMu.Unlock()
+	valueFormatters = append(valueFormatters, f)
+}
+
+func init() {
+	RegisterValueFormatter(renderRawJSON)
+	RegisterValueFormatter(renderURLValues)
+	RegisterValueFormatter(renderGraphQL)
+}
+
+// renderRawJSON pretty-prints json.RawMessage values and strings that parse
+// as a JSON object or array.
+func renderRawJSON(v interface{}) (string, bool) {
+	var data []byte
+	switch t := v.(type) {
+	case json.RawMessage:
+		data = []byte(t)
+	case []byte:
+		data = t
+	case string:
+		trimmed := strings.TrimSpace(t)
+		if trimmed == "" || (trimmed[0] != '{' && trimmed[0] != '[') {
+			return "", false
+		}
+		data = []byte(trimmed)
+	default:
+		return "", false
+	}
+	return renderJSONValue(data)
+}
+
+// renderJSONValue tokenizes data and emits ANSI codes per token class,
+// mirroring what gh's jsonpretty does, rather than unmarshaling into a
+// generic value and re-marshaling (which would lose key order and mangle
+// number formatting).
+func renderJSONValue(data []byte) (string, bool) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var buf bytes.Buffer
+	if err := writeJSONValue(&buf, dec, 0); err != nil {
+		return "", false
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return "", false
+	}
+	return buf.String(), true
+}
+
+func writeJSONValue(buf *bytes.Buffer, dec *json.Decoder, depth int) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	return writeJSONToken(buf, dec, tok, depth)
+}
+
+func writeJSONToken(buf *bytes.Buffer, dec *json.Decoder, tok json.Token, depth int) error {
+	switch t := tok.(type) {
+	case json.Delim:
+		switch t {
+		case '{':
+			return writeJSONObject(buf, dec, depth)
+		case '[':
+			return writeJSONArray(buf, dec, depth)
+		}
+	case string:
+		buf.WriteString(theme.Value)
+		buf.WriteString(strconv.Quote(t))
+		buf.WriteString(ansi.Reset)
+	case json.Number:
+		buf.WriteString(theme.Misc)
+		buf.WriteString(t.String())
+		buf.WriteString(ansi.Reset)
+	case bool:
+		buf.WriteString(theme.Misc)
+		if t {
+			buf.WriteString("true")
+		} else {
+			buf.WriteString("false")
+		}
+		buf.WriteString(ansi.Reset)
+	case nil:
+		buf.WriteString(theme.Misc)
+		buf.WriteString("null")
+		buf.WriteString(ansi.Reset)
+	}
+	return nil
+}
+
+func writeJSONObject(buf *bytes.Buffer, dec *json.Decoder, depth int) error {
+	buf.WriteString(theme.Misc + "{" + ansi.Reset)
+	childIndent := strings.Repeat(indent, depth+1)
+	any := false
+	for dec.More() {
+		if !any {
+			buf.WriteString("\n")
+		} else {
+			buf.WriteString(theme.Misc + "," + ansi.Reset + "\n")
+		}
+		any = true
+		buf.WriteString(childIndent)
+
+		keyTok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, _ := keyTok.(string)
+		buf.WriteString(theme.Key)
+		buf.WriteString(strconv.Quote(key))
+		buf.WriteString(ansi.Reset)
+		buf.WriteString(theme.Misc + ":" + ansi.Reset + " ")
+
+		if err := writeJSONValue(buf, dec, depth+1); err != nil {
+			return err
+		}
+	}
+	if _, err := dec.Token(); err != nil { // consume '}'
+		return err
+	}
+	if any {
+		buf.WriteString("\n")
+		buf.WriteString(strings.Repeat(indent, depth))
+	}
+	buf.WriteString(theme.Misc + "}" + ansi.Reset)
+	return nil
+}
+
+func writeJSONArray(buf *bytes.Buffer, dec *json.Decoder, depth int) error {
+	buf.WriteString(theme.Misc + "[" + ansi.Reset)
+	childIndent := strings.Repeat(indent, depth+1)
+	any := false
+	for dec.More() {
+		if !any {
+			buf.WriteString("\n")
+		} else {
+			buf.WriteString(theme.Misc + "," + ansi.Reset + "\n")
+		}
+		any = true
+		buf.WriteString(childIndent)
+
+		if err := writeJSONValue(buf, dec, depth+1); err != nil {
+			return err
+		}
+	}
+	if _, err := dec.Token(); err != nil { // consume ']'
+		return err
+	}
+	if any {
+		buf.WriteString("\n")
+		buf.WriteString(strings.Repeat(indent, depth))
+	}
+	buf.WriteString(theme.Misc + "]" + ansi.Reset)
+	return nil
+}
+
+// renderURLValues pretty prints a url.Values, one "key=v1, v2" pair per
+// line, so form data dumps don't need a trip through jq to read.
+func renderURLValues(v interface{}) (string, bool) {
+	vals, ok := v.(url.Values)
+	if !ok {
+		return "", false
+	}
+
+	keys := make([]string, 0, len(vals))
+	for k := range vals {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	for i, k := range keys {
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+		buf.WriteString(theme.Key)
+		buf.WriteString(k)
+		buf.WriteString(ansi.Reset)
+		buf.WriteString(assignmentChar)
+		buf.WriteString(theme.Value)
+		buf.WriteString(strings.Join(vals[k], ", "))
+		buf.WriteString(ansi.Reset)
+	}
+	return buf.String(), true
+}
+
+var (
+	graphQLKeywords = map[string]bool{
+		"query": true, "mutation": true, "subscription": true,
+		"fragment": true, "on": true,
+	}
+	graphQLTokenRe       = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_]*|[{}():]|\s+`)
+	graphQLLeadingWordRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*`)
+)
+
+// looksLikeGraphQL is a light heuristic: a leading query/mutation/
+// subscription/fragment keyword, or a bare selection set ("{ ... }"). The
+// keyword is matched on its own word boundary rather than via
+// strings.Fields, since a parenthesized variable list right after the
+// keyword ("query($id: ID!) {...}") leaves no space for Fields to split on.
+func looksLikeGraphQL(s string) bool {
+	trimmed := strings.TrimSpace(s)
+	if trimmed == "" {
+		return false
+	}
+	switch graphQLLeadingWordRe.FindString(trimmed) {
+	case "query", "mutation", "subscription", "fragment":
+		return true
+	}
+	return strings.HasPrefix(trimmed, "{") && strings.Contains(trimmed, "}")
+}
+
+// renderGraphQL colorizes GraphQL keywords, punctuation and field names. The
+// query's own whitespace and line breaks are kept as they are: a query
+// logged on one line is not re-indented or split across lines.
+func renderGraphQL(v interface{}) (string, bool) {
+	s, ok := v.(string)
+	if !ok || !looksLikeGraphQL(s) {
+		return "", false
+	}
+
+	var buf bytes.Buffer
+	last := 0
+	for _, loc := range graphQLTokenRe.FindAllStringIndex(s, -1) {
+		// pass through whatever the token regex doesn't recognize (sigils,
+		// punctuation, quotes, ...) verbatim, instead of silently dropping it
+		if loc[0] > last {
+			buf.WriteString(s[last:loc[0]])
+		}
+		last = loc[1]
+
+		tok := s[loc[0]:loc[1]]
+		switch {
+		case strings.TrimSpace(tok) == "":
+			buf.WriteString(tok)
+		case graphQLKeywords[tok]:
+			buf.WriteString(theme.Key + tok + ansi.Reset)
+		case tok == "{" || tok == "}" || tok == "(" || tok == ")" || tok == ":":
+			buf.WriteString(theme.Misc + tok + ansi.Reset)
+		default:
+			buf.WriteString(theme.Value + tok + ansi.Reset)
+		}
+	}
+	if last < len(s) {
+		buf.WriteString(s[last:])
+	}
+	return buf.String(), true
+}
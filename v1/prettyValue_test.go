@@ -0,0 +1,50 @@
+package log
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderGraphQL(t *testing.T) {
+	theme = parseTheme("")
+
+	cases := []struct {
+		name string
+		in   string
+	}{
+		{"keyword and braces", "query { viewer { login } }"},
+		{"variable sigil", "query($id: ID!) { node(id: $id) { id } }"},
+		{"string literal", `mutation { login(password: "a,b:c") }`},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			out, ok := renderGraphQL(c.in)
+			if !ok {
+				t.Fatalf("renderGraphQL(%q) returned ok=false", c.in)
+			}
+			stripped := stripANSI(out)
+			if stripped != c.in {
+				t.Fatalf("renderGraphQL(%q) dropped bytes, got %q", c.in, stripped)
+			}
+		})
+	}
+}
+
+// stripANSI removes the color codes renderGraphQL wraps tokens in, so the
+// rendered output can be compared back against the original input.
+func stripANSI(s string) string {
+	var buf strings.Builder
+	inEscape := false
+	for _, r := range s {
+		switch {
+		case r == '\x1b':
+			inEscape = true
+		case inEscape && r == 'm':
+			inEscape = false
+		case !inEscape:
+			buf.WriteRune(r)
+		}
+	}
+	return buf.String()
+}
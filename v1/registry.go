@@ -0,0 +1,80 @@
+package log
+
+import "sync"
+
+// Registry is a hierarchical collection of named loggers, modeled after
+// gommon's logger groups. A Registry owns a root logger and lazily creates
+// children on demand, so an application can reach into a subtree (e.g.
+// "app.db") and recursively tune its level or source-location reporting
+// without restarting.
+type Registry struct {
+	mu       sync.Mutex
+	name     string
+	logger   Logger
+	children map[string]*Registry
+}
+
+// NewRegistry creates a Registry rooted at name. The root logger is created
+// the same way log.New(name) would create a standalone logger.
+func NewRegistry(name string) *Registry {
+	return &Registry{
+		name:     name,
+		logger:   New(name),
+		children: map[string]*Registry{},
+	}
+}
+
+// Logger returns the child registry for subname, creating it (and its
+// logger, named "parent.subname") if it doesn't exist yet.
+func (r *Registry) Logger(subname string) *Registry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	child, ok := r.children[subname]
+	if !ok {
+		child = NewRegistry(r.name + "." + subname)
+		r.children[subname] = child
+	}
+	return child
+}
+
+// Log returns the Logger this registry node owns.
+func (r *Registry) Log() Logger {
+	return r.logger
+}
+
+// Name returns the dotted name of this registry node.
+func (r *Registry) Name() string {
+	return r.name
+}
+
+// walk calls fn for r and every descendant registered under it.
+func (r *Registry) walk(fn func(*Registry)) {
+	r.mu.Lock()
+	children := make([]*Registry, 0, len(r.children))
+	for _, child := range r.children {
+		children = append(children, child)
+	}
+	r.mu.Unlock()
+
+	fn(r)
+	for _, child := range children {
+		child.walk(fn)
+	}
+}
+
+// SetLevelRecursive sets level on registry's logger and every descendant
+// logger registered under it.
+func SetLevelRecursive(registry *Registry, level int) {
+	registry.walk(func(r *Registry) {
+		r.logger.SetLevel(level)
+	})
+}
+
+// EnableSourceRecursive turns on source-location reporting for registry's
+// logger and every descendant logger registered under it.
+func EnableSourceRecursive(registry *Registry) {
+	registry.walk(func(r *Registry) {
+		r.logger.EnableSource(true)
+	})
+}
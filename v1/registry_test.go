@@ -0,0 +1,33 @@
+package log
+
+import "testing"
+
+func TestSetLevelRecursive(t *testing.T) {
+	reg := NewRegistry("app")
+	child := reg.Logger("db")
+
+	SetLevelRecursive(reg, LevelWarn)
+
+	if reg.Log().Level() != LevelWarn {
+		t.Fatalf("root level = %d, want %d", reg.Log().Level(), LevelWarn)
+	}
+	if child.Log().Level() != LevelWarn {
+		t.Fatalf("child level = %d, want %d", child.Log().Level(), LevelWarn)
+	}
+}
+
+func TestEnableSourceRecursive(t *testing.T) {
+	reg := NewRegistry("app")
+	child := reg.Logger("db")
+
+	EnableSourceRecursive(reg)
+
+	root, ok := reg.Log().(*DefaultLogger)
+	if !ok || !root.addSource {
+		t.Fatalf("EnableSourceRecursive did not enable source on root logger")
+	}
+	dbLogger, ok := child.Log().(*DefaultLogger)
+	if !ok || !dbLogger.addSource {
+		t.Fatalf("EnableSourceRecursive did not enable source on child logger")
+	}
+}
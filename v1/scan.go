@@ -0,0 +1,134 @@
+package log
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+)
+
+// ScanOptions configures Scan.
+type ScanOptions struct {
+	// Name is used when constructing the HappyDevFormatter Scan re-renders
+	// entries through. Defaults to "scan".
+	Name string
+}
+
+// Scan reads newline-delimited JSON log entries from r -- the exact shape
+// JSONFormatter emits, plus common variants like logrus and slog -- and
+// re-emits them through HappyDevFormatter to w. This mirrors what humanlog
+// does for other loggers and lets production services keep JSON on disk
+// while developers pipe `kubectl logs` or `journalctl` through a formatter
+// for color, stack expansion and aligned keys.
+//
+// Lines that aren't a JSON object pass through verbatim. Unknown-shape JSON
+// falls back to printing the known fields (time, level/lvl/severity,
+// msg/message) with everything else rendered as generic key/value pairs.
+func Scan(r io.Reader, w io.Writer, opts *ScanOptions) error {
+	if opts == nil {
+		opts = &ScanOptions{}
+	}
+	name := opts.Name
+	if name == "" {
+		name = "scan"
+	}
+	hd := NewHappyDevFormatter(name)
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if err := scanLine(hd, w, scanner.Text()); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+func scanLine(hd *HappyDevFormatter, w io.Writer, line string) (err error) {
+	trimmed := strings.TrimSpace(line)
+
+	var raw map[string]interface{}
+	if trimmed == "" || trimmed[0] != '{' || json.Unmarshal([]byte(trimmed), &raw) != nil {
+		_, err = io.WriteString(w, line+"\n")
+		return err
+	}
+
+	level, msg, args := decodeEntry(raw)
+
+	// Format panics (via InternalLog.Fatal) on a key it considers reserved
+	// or otherwise invalid; decodeEntry already renames logxi's own
+	// reserved keys, but a third-party source could still produce
+	// something unexpected, and one bad line must not kill the whole tail.
+	defer func() {
+		if r := recover(); r != nil {
+			_, err = io.WriteString(w, line+"\n")
+		}
+	}()
+
+	var buf bytes.Buffer
+	hd.Format(&buf, level, msg, args)
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+// decodeEntry pulls the fields Scan understands (logxi's own reserved keys,
+// plus the logrus/slog-style time/level/msg trio) out of a generic JSON
+// object, leaving the rest to be rendered as ordinary key/value pairs. The
+// entry's own time, if it is a string, is kept as the first pair, "time",
+// since Format stamps the head of the line with the time of re-rendering.
+func decodeEntry(raw map[string]interface{}) (level int, msg string, args []interface{}) {
+	msg = firstString(raw, messageKey, "msg", "message")
+	level = parseLevel(firstString(raw, levelKey, "level", "lvl", "severity"))
+
+	skip := map[string]bool{
+		timeKey: true, levelKey: true, messageKey: true, nameKey: true,
+		"time": true, "level": true, "lvl": true, "severity": true,
+		"msg": true, "message": true,
+	}
+
+	keys := make([]string, 0, len(raw))
+	for k := range raw {
+		if !skip[k] {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	if t := firstString(raw, timeKey, "time"); t != "" {
+		args = append(args, "time", t)
+	}
+	for _, k := range keys {
+		// an unrelated JSON source can easily produce one of logxi's own
+		// single-rune reserved keys (_t, _l, _n, _m, _p, _c); rename it
+		// rather than let Format's reserved-key check panic on it.
+		key := k
+		if reserved, rerr := isReservedKey(key); rerr == nil && reserved {
+			key = key + "_field"
+		}
+		args = append(args, key, raw[k])
+	}
+	return level, msg, args
+}
+
+func firstString(raw map[string]interface{}, keys ...string) string {
+	for _, k := range keys {
+		if s, ok := raw[k].(string); ok {
+			return s
+		}
+	}
+	return ""
+}
+
+func parseLevel(s string) int {
+	switch strings.ToLower(s) {
+	case "debug", "dbg", "trace":
+		return LevelDebug
+	case "warn", "warning", "wrn":
+		return LevelWarn
+	case "error", "err", "fatal", "panic":
+		return LevelError
+	default:
+		return LevelInfo
+	}
+}
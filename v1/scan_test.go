@@ -0,0 +1,54 @@
+package log
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestScanLinePassesThroughNonJSON(t *testing.T) {
+	theme = parseTheme("")
+	hd := NewHappyDevFormatter("scan")
+
+	var out strings.Builder
+	if err := scanLine(hd, &out, "plain text log line"); err != nil {
+		t.Fatalf("scanLine returned error: %v", err)
+	}
+	if out.String() != "plain text log line\n" {
+		t.Fatalf("scanLine mangled a non-JSON line: %q", out.String())
+	}
+}
+
+func TestScanLineRenamesCollidingReservedKeys(t *testing.T) {
+	theme = parseTheme("")
+	hd := NewHappyDevFormatter("scan")
+
+	// "_t" is one of logxi's own single-rune reserved keys; a third-party
+	// JSON source producing it must not crash the whole tail.
+	line := `{"msg":"hi","_t":"not a logxi timestamp"}`
+
+	var out strings.Builder
+	if err := scanLine(hd, &out, line); err != nil {
+		t.Fatalf("scanLine returned error on colliding key: %v", err)
+	}
+	if !strings.Contains(out.String(), "_t_field") {
+		t.Fatalf("scanLine didn't rename the colliding reserved key: %q", out.String())
+	}
+}
+
+func TestDecodeEntryExtractsKnownFields(t *testing.T) {
+	raw := map[string]interface{}{
+		"level": "warn",
+		"msg":   "disk low",
+		"extra": "field",
+	}
+	level, msg, args := decodeEntry(raw)
+	if level != LevelWarn {
+		t.Fatalf("level = %d, want LevelWarn", level)
+	}
+	if msg != "disk low" {
+		t.Fatalf("msg = %q, want %q", msg, "disk low")
+	}
+	if len(args) != 2 || args[0] != "extra" || args[1] != "field" {
+		t.Fatalf("args = %v, want [extra field]", args)
+	}
+}
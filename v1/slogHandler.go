@@ -0,0 +1,265 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+)
+
+// HandlerOptions configures the slog.Handler adapters returned by
+// NewHappyDevHandler and NewJSONHandler.
+type HandlerOptions struct {
+	// AddSource causes the NewHappyDevHandler handler to attach file:line
+	// context to Debug and Info records too, using the same getLevelContext
+	// stack-trace logic HappyDevFormatter already applies to WARN/ERROR.
+	// The NewJSONHandler handler ignores it.
+	AddSource bool
+
+	// Level reports the minimum record level that will be logged. A nil
+	// Level defaults to slog.LevelInfo.
+	Level slog.Leveler
+}
+
+func slogLevelToLxi(level slog.Level) int {
+	switch {
+	case level < slog.LevelInfo:
+		return LevelDebug
+	case level < slog.LevelWarn:
+		return LevelInfo
+	case level < slog.LevelError:
+		return LevelWarn
+	default:
+		return LevelError
+	}
+}
+
+// groupPrefix joins a group path as logxi expects it in a flat key, e.g.
+// []string{"req", "header"} -> "req.header.".
+func groupPrefix(groups []string) string {
+	if len(groups) == 0 {
+		return ""
+	}
+	return strings.Join(groups, ".") + "."
+}
+
+// flattenAttr appends key/value pairs for args, flattening nested groups
+// into dotted keys rooted at prefix.
+func flattenAttr(args []interface{}, prefix string, a slog.Attr) []interface{} {
+	a.Value = a.Value.Resolve()
+	if a.Value.Kind() == slog.KindGroup {
+		nested := prefix + a.Key + "."
+		for _, ga := range a.Value.Group() {
+			args = flattenAttr(args, nested, ga)
+		}
+		return args
+	}
+	return append(args, prefix+a.Key, a.Value.Any())
+}
+
+// nestAttr inserts a into dst, turning nested groups into nested
+// map[string]interface{} values rather than dotted keys.
+func nestAttr(dst map[string]interface{}, a slog.Attr) {
+	a.Value = a.Value.Resolve()
+	if a.Value.Kind() == slog.KindGroup {
+		group, ok := dst[a.Key].(map[string]interface{})
+		if !ok {
+			group = map[string]interface{}{}
+			dst[a.Key] = group
+		}
+		for _, ga := range a.Value.Group() {
+			nestAttr(group, ga)
+		}
+		return
+	}
+	dst[a.Key] = a.Value.Any()
+}
+
+// attrSegment is a WithAttrs call frozen together with the group path that
+// was open when it was made, so a later WithGroup doesn't retroactively
+// nest attrs that were already attached to an outer group.
+type attrSegment struct {
+	prefix string
+	attrs  []slog.Attr
+}
+
+// happyDevHandler adapts HappyDevFormatter to slog.Handler.
+type happyDevHandler struct {
+	mu     *sync.Mutex
+	w      io.Writer
+	hd     *HappyDevFormatter
+	opts   HandlerOptions
+	groups []string
+	segs   []attrSegment
+}
+
+// NewHappyDevHandler returns a slog.Handler that renders records through
+// HappyDevFormatter, so applications built against log/slog can opt into
+// logxi's colorized, column-aligned console output without reimplementing
+// their logging stack.
+func NewHappyDevHandler(w io.Writer, opts *HandlerOptions) slog.Handler {
+	if opts == nil {
+		opts = &HandlerOptions{}
+	}
+	hd := NewHappyDevFormatter("slog")
+	// NewHappyDevFormatter detects against os.Stdout; detect against w, the
+	// handler's own sink, after it so that result is the one that stands
+	autoDetectColors(w)
+	return &happyDevHandler{
+		mu:   &sync.Mutex{},
+		w:    w,
+		hd:   hd,
+		opts: *opts,
+	}
+}
+
+func (h *happyDevHandler) Enabled(_ context.Context, level slog.Level) bool {
+	min := slog.LevelInfo
+	if h.opts.Level != nil {
+		min = h.opts.Level.Level()
+	}
+	return level >= min
+}
+
+func (h *happyDevHandler) Handle(_ context.Context, r slog.Record) error {
+	args := make([]interface{}, 0, 2*r.NumAttrs())
+	for _, seg := range h.segs {
+		for _, a := range seg.attrs {
+			args = flattenAttr(args, seg.prefix, a)
+		}
+	}
+	prefix := groupPrefix(h.groups)
+	r.Attrs(func(a slog.Attr) bool {
+		args = flattenAttr(args, prefix, a)
+		return true
+	})
+
+	// Format mutates hd.col, and WithAttrs/WithGroup clones all share the
+	// same *HappyDevFormatter, so the lock has to cover Format too, not
+	// just the Write -- slog.Handler.Handle must be safe for concurrent use.
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var buf bytes.Buffer
+	h.hd.FormatWithSource(&buf, slogLevelToLxi(r.Level), r.Message, args, h.opts.AddSource)
+
+	_, err := h.w.Write(buf.Bytes())
+	return err
+}
+
+func (h *happyDevHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	h2 := *h
+	h2.segs = append(append([]attrSegment{}, h.segs...), attrSegment{
+		prefix: groupPrefix(h.groups),
+		attrs:  attrs,
+	})
+	return &h2
+}
+
+func (h *happyDevHandler) WithGroup(name string) slog.Handler {
+	h2 := *h
+	h2.groups = append(append([]string{}, h.groups...), name)
+	return &h2
+}
+
+// groupSegment is a WithAttrs call frozen together with the group path
+// (as a slice, not yet joined) that was open when it was made.
+type groupSegment struct {
+	groups []string
+	attrs  []slog.Attr
+}
+
+// jsonHandler adapts JSONFormatter to slog.Handler.
+type jsonHandler struct {
+	mu     *sync.Mutex
+	w      io.Writer
+	jf     *JSONFormatter
+	opts   HandlerOptions
+	groups []string
+	segs   []groupSegment
+}
+
+// NewJSONHandler returns a slog.Handler that renders records through
+// JSONFormatter, preserving logxi's reserved-key checks and field layout.
+// Unlike NewHappyDevHandler, grouped attributes are emitted as nested JSON
+// objects rather than dotted keys.
+func NewJSONHandler(w io.Writer, opts *HandlerOptions) slog.Handler {
+	if opts == nil {
+		opts = &HandlerOptions{}
+	}
+	return &jsonHandler{
+		mu:   &sync.Mutex{},
+		w:    w,
+		jf:   NewJSONFormatter("slog"),
+		opts: *opts,
+	}
+}
+
+func (h *jsonHandler) Enabled(_ context.Context, level slog.Level) bool {
+	min := slog.LevelInfo
+	if h.opts.Level != nil {
+		min = h.opts.Level.Level()
+	}
+	return level >= min
+}
+
+func (h *jsonHandler) Handle(_ context.Context, r slog.Record) error {
+	root := map[string]interface{}{}
+	for _, seg := range h.segs {
+		insertAttrs(root, seg.groups, seg.attrs)
+	}
+
+	var rAttrs []slog.Attr
+	r.Attrs(func(a slog.Attr) bool {
+		rAttrs = append(rAttrs, a)
+		return true
+	})
+	insertAttrs(root, h.groups, rAttrs)
+
+	args := make([]interface{}, 0, 2*len(root))
+	for k, v := range root {
+		args = append(args, k, v)
+	}
+
+	var buf bytes.Buffer
+	h.jf.Format(&buf, slogLevelToLxi(r.Level), r.Message, args)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err := h.w.Write(buf.Bytes())
+	return err
+}
+
+// insertAttrs walks root to the nested map named by groups, creating any
+// missing levels, then nests each of attrs into it.
+func insertAttrs(root map[string]interface{}, groups []string, attrs []slog.Attr) {
+	m := root
+	for _, g := range groups {
+		child, ok := m[g].(map[string]interface{})
+		if !ok {
+			child = map[string]interface{}{}
+			m[g] = child
+		}
+		m = child
+	}
+	for _, a := range attrs {
+		nestAttr(m, a)
+	}
+}
+
+func (h *jsonHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	h2 := *h
+	h2.segs = append(append([]groupSegment{}, h.segs...), groupSegment{
+		groups: append([]string{}, h.groups...),
+		attrs:  attrs,
+	})
+	return &h2
+}
+
+func (h *jsonHandler) WithGroup(name string) slog.Handler {
+	h2 := *h
+	h2.groups = append(append([]string{}, h.groups...), name)
+	return &h2
+}
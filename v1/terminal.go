@@ -0,0 +1,35 @@
+package log
+
+import (
+	"io"
+	"os"
+)
+
+// ShouldColorize reports whether w should receive ANSI color codes:
+// unconditionally true when FORCE_COLOR is set in the environment, and
+// otherwise whatever the platform's isatty/ioctl (or GetConsoleMode on
+// Windows) detection decides -- false for a pipe or a redirected file, true
+// for an interactive terminal. Non-*os.File writers (buffers, network
+// connections, ...) are treated as non-terminals.
+func ShouldColorize(w io.Writer) bool {
+	if os.Getenv("FORCE_COLOR") != "" {
+		return true
+	}
+
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	return isTerminal(f.Fd())
+}
+
+// autoDetectColors applies ShouldColorize(w) to the global color switch,
+// unless the caller already made an explicit choice via DisableColors. This
+// is what lets `myapp | tee log.txt` yield a clean file while an
+// interactive run stays colored, without any env-var dance.
+func autoDetectColors(w io.Writer) {
+	if colorsExplicit {
+		return
+	}
+	disableColors = !ShouldColorize(w)
+}
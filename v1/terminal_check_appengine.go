@@ -0,0 +1,10 @@
+//go:build appengine
+// +build appengine
+
+package log
+
+// isTerminal is always false on App Engine's classic sandbox; fds aren't
+// something user code can inspect there.
+func isTerminal(fd uintptr) bool {
+	return false
+}
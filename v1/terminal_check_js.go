@@ -0,0 +1,9 @@
+//go:build js
+// +build js
+
+package log
+
+// isTerminal is always false under js/wasm; there's no tty to inspect.
+func isTerminal(fd uintptr) bool {
+	return false
+}
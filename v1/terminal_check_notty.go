@@ -0,0 +1,10 @@
+//go:build nacl || plan9
+// +build nacl plan9
+
+package log
+
+// isTerminal is always false on platforms with no isatty equivalent we can
+// reach from here.
+func isTerminal(fd uintptr) bool {
+	return false
+}
@@ -0,0 +1,11 @@
+//go:build solaris
+// +build solaris
+
+package log
+
+import "golang.org/x/sys/unix"
+
+func isTerminal(fd uintptr) bool {
+	_, err := unix.IoctlGetTermio(int(fd), unix.TCGETA)
+	return err == nil
+}
@@ -0,0 +1,37 @@
+package log
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func TestShouldColorizeNonFileWriterIsFalse(t *testing.T) {
+	os.Unsetenv("FORCE_COLOR")
+	if ShouldColorize(&bytes.Buffer{}) {
+		t.Fatalf("ShouldColorize(bytes.Buffer) = true, want false")
+	}
+}
+
+func TestShouldColorizeForceColorWins(t *testing.T) {
+	os.Setenv("FORCE_COLOR", "1")
+	defer os.Unsetenv("FORCE_COLOR")
+
+	if !ShouldColorize(&bytes.Buffer{}) {
+		t.Fatalf("ShouldColorize with FORCE_COLOR set = false, want true")
+	}
+}
+
+func TestAutoDetectColorsLeavesExplicitChoiceAlone(t *testing.T) {
+	os.Unsetenv("FORCE_COLOR")
+	DisableColors(true)
+	defer func() {
+		disableColors = false
+		colorsExplicit = false
+	}()
+
+	autoDetectColors(&bytes.Buffer{})
+	if !disableColors {
+		t.Fatalf("autoDetectColors overrode an explicit DisableColors(true) call")
+	}
+}